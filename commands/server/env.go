@@ -0,0 +1,62 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/Southclaws/sampctl/runtime"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// EnvCommand prints the SAMP_* environment variables for the server config in the current
+// directory, for use in Docker/Kubernetes manifests or other headless deployments.
+var EnvCommand = &cli.Command{
+	Name:  "env",
+	Usage: "print the samp.json config as SAMP_* environment variables",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "output format: 'plain' (KEY=value) or 'export' (export KEY=value)",
+			Value: "plain",
+		},
+		&cli.StringFlag{
+			Name:    "profile",
+			Usage:   "samp.<profile>.json overlay to apply, overrides SAMPCTL_PROFILE",
+			EnvVars: []string{"SAMPCTL_PROFILE"},
+		},
+	},
+	Action: envAction,
+}
+
+func envAction(c *cli.Context) (err error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return errors.Wrap(err, "failed to get working directory")
+	}
+
+	cfg, err := runtime.ConfigFromDirectoryWithProfile(dir, c.String("profile"))
+	if err != nil {
+		return errors.Wrap(err, "failed to load config")
+	}
+
+	vars := cfg.EnvironmentVariables()
+
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	prefix := ""
+	if c.String("format") == "export" {
+		prefix = "export "
+	}
+
+	for _, k := range keys {
+		fmt.Fprintf(c.App.Writer, "%s%s=%s\n", prefix, k, vars[k])
+	}
+
+	return nil
+}
@@ -0,0 +1,12 @@
+package server
+
+import "github.com/urfave/cli/v2"
+
+// ServerCommand groups the subcommands for inspecting and running a configured SA:MP server.
+var ServerCommand = &cli.Command{
+	Name:  "server",
+	Usage: "manage and inspect the SA:MP server configured in the current directory",
+	Subcommands: []*cli.Command{
+		EnvCommand,
+	},
+}
@@ -0,0 +1,174 @@
+// Package dynconfig evaluates dynamic configuration sources (Starlark and Jsonnet) into the
+// plain JSON bytes that runtime.Config is normally unmarshalled from. This lets a samp.star or
+// samp.jsonnet file compute a Config programmatically instead of hand-writing samp.json.
+package dynconfig
+
+import (
+	"encoding/json"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/google/go-jsonnet"
+	"github.com/pkg/errors"
+	"go.starlark.net/starlark"
+)
+
+// Context describes the environment a dynamic config file is evaluated with. It is made
+// available to both Starlark and Jsonnet so either can branch on OS, arch or env vars.
+type Context struct {
+	OS   string            `json:"os"`
+	Arch string            `json:"arch"`
+	Env  map[string]string `json:"env"`
+}
+
+// NewContext builds a Context from the current process environment.
+func NewContext() (ctx Context) {
+	ctx.OS = runtime.GOOS
+	ctx.Arch = runtime.GOARCH
+	ctx.Env = make(map[string]string)
+	for _, kv := range os.Environ() {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok {
+			continue
+		}
+		ctx.Env[key] = value
+	}
+	return
+}
+
+// EvalStarlark executes the given .star file's `main(ctx)` function and returns the JSON
+// encoding of whatever dict it returns. The dict is expected to mirror the Config JSON shape.
+func EvalStarlark(file string, ctx Context) (contents []byte, err error) {
+	thread := &starlark.Thread{Name: "dynconfig"}
+
+	globals, err := starlark.ExecFile(thread, file, nil, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to execute starlark file")
+		return
+	}
+
+	main, ok := globals["main"]
+	if !ok {
+		err = errors.New("starlark file does not define a main(ctx) function")
+		return
+	}
+
+	ctxValue, err := toStarlarkValue(ctx)
+	if err != nil {
+		err = errors.Wrap(err, "failed to convert context to starlark value")
+		return
+	}
+
+	result, err := starlark.Call(thread, main, starlark.Tuple{ctxValue}, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to call main(ctx)")
+		return
+	}
+
+	dict, ok := result.(*starlark.Dict)
+	if !ok {
+		err = errors.New("main(ctx) must return a dict")
+		return
+	}
+
+	contents, err = json.Marshal(fromStarlarkDict(dict))
+	if err != nil {
+		err = errors.Wrap(err, "failed to marshal starlark result to json")
+		return
+	}
+
+	return
+}
+
+// EvalJsonnet evaluates the given .jsonnet file with `os`, `arch` and `env` top-level arguments
+// and returns the resulting JSON document.
+func EvalJsonnet(file string, ctx Context) (contents []byte, err error) {
+	vm := jsonnet.MakeVM()
+	vm.TLACode("os", quote(ctx.OS))
+	vm.TLACode("arch", quote(ctx.Arch))
+
+	env, err := json.Marshal(ctx.Env)
+	if err != nil {
+		err = errors.Wrap(err, "failed to marshal env for jsonnet")
+		return
+	}
+	vm.TLACode("env", string(env))
+
+	out, err := vm.EvaluateFile(file)
+	if err != nil {
+		err = errors.Wrap(err, "failed to evaluate jsonnet file")
+		return
+	}
+
+	contents = []byte(out)
+
+	return
+}
+
+func quote(s string) string {
+	b, _ := json.Marshal(s) // nolint:errcheck - strings always marshal
+	return string(b)
+}
+
+// toStarlarkValue converts a Context into the starlark dict passed to main(ctx), so scripts
+// access it as ctx["os"]/ctx["arch"]/ctx["env"] rather than attribute access.
+func toStarlarkValue(ctx Context) (starlark.Value, error) {
+	env := starlark.NewDict(len(ctx.Env))
+	for k, v := range ctx.Env {
+		if err := env.SetKey(starlark.String(k), starlark.String(v)); err != nil {
+			return nil, err
+		}
+	}
+
+	dict := starlark.NewDict(3)
+	if err := dict.SetKey(starlark.String("os"), starlark.String(ctx.OS)); err != nil {
+		return nil, err
+	}
+	if err := dict.SetKey(starlark.String("arch"), starlark.String(ctx.Arch)); err != nil {
+		return nil, err
+	}
+	if err := dict.SetKey(starlark.String("env"), env); err != nil {
+		return nil, err
+	}
+
+	return dict, nil
+}
+
+// fromStarlarkDict converts a starlark.Dict into a plain map[string]interface{} suitable for
+// json.Marshal, recursing into nested dicts and lists.
+func fromStarlarkDict(dict *starlark.Dict) map[string]interface{} {
+	out := make(map[string]interface{})
+	for _, item := range dict.Items() {
+		key, ok := starlark.AsString(item[0])
+		if !ok {
+			continue
+		}
+		out[key] = fromStarlarkValue(item[1])
+	}
+	return out
+}
+
+func fromStarlarkValue(v starlark.Value) interface{} {
+	switch value := v.(type) {
+	case starlark.String:
+		return string(value)
+	case starlark.Bool:
+		return bool(value)
+	case starlark.Int:
+		i, _ := value.Int64()
+		return i
+	case starlark.Float:
+		return float64(value)
+	case *starlark.Dict:
+		return fromStarlarkDict(value)
+	case *starlark.List:
+		items := make([]interface{}, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			items = append(items, fromStarlarkValue(value.Index(i)))
+		}
+		return items
+	default:
+		return value.String()
+	}
+}
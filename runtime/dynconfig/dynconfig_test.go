@@ -0,0 +1,176 @@
+package dynconfig
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.starlark.net/starlark"
+)
+
+func TestNewContext_PopulatesEnvFromRealPairs(t *testing.T) {
+	os.Setenv("DYNCONFIG_TEST_VAR", "hello") // nolint:errcheck
+	defer os.Unsetenv("DYNCONFIG_TEST_VAR")  // nolint:errcheck
+
+	ctx := NewContext()
+
+	if ctx.Env["DYNCONFIG_TEST_VAR"] != "hello" {
+		t.Fatalf("expected DYNCONFIG_TEST_VAR=hello in ctx.Env, got %v", ctx.Env["DYNCONFIG_TEST_VAR"])
+	}
+	if ctx.OS == "" || ctx.Arch == "" {
+		t.Fatalf("expected OS and Arch to be populated, got os=%q arch=%q", ctx.OS, ctx.Arch)
+	}
+}
+
+func TestToStarlarkValue_ExposesContextAsDict(t *testing.T) {
+	ctx := Context{OS: "linux", Arch: "amd64", Env: map[string]string{"FOO": "bar"}}
+
+	value, err := toStarlarkValue(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dict, ok := value.(*starlark.Dict)
+	if !ok {
+		t.Fatalf("expected *starlark.Dict, got %T", value)
+	}
+
+	osVal, found, err := dict.Get(starlark.String("os"))
+	if err != nil || !found {
+		t.Fatalf("expected ctx[\"os\"] to be set, found=%v err=%v", found, err)
+	}
+	if osVal.(starlark.String) != "linux" {
+		t.Fatalf("expected ctx[\"os\"] == \"linux\", got %v", osVal)
+	}
+
+	envVal, found, err := dict.Get(starlark.String("env"))
+	if err != nil || !found {
+		t.Fatalf("expected ctx[\"env\"] to be set, found=%v err=%v", found, err)
+	}
+	envDict, ok := envVal.(*starlark.Dict)
+	if !ok {
+		t.Fatalf("expected ctx[\"env\"] to be a dict, got %T", envVal)
+	}
+	fooVal, found, err := envDict.Get(starlark.String("FOO"))
+	if err != nil || !found || fooVal.(starlark.String) != "bar" {
+		t.Fatalf("expected ctx[\"env\"][\"FOO\"] == \"bar\", found=%v err=%v val=%v", found, err, fooVal)
+	}
+}
+
+func TestFromStarlarkDict_RecursesIntoNestedDictsAndLists(t *testing.T) {
+	inner := starlark.NewDict(1)
+	if err := inner.SetKey(starlark.String("port"), starlark.MakeInt(7777)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	list := starlark.NewList([]starlark.Value{starlark.String("a"), starlark.String("b")})
+
+	outer := starlark.NewDict(2)
+	if err := outer.SetKey(starlark.String("nested"), inner); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := outer.SetKey(starlark.String("items"), list); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := fromStarlarkDict(outer)
+
+	nested, ok := out["nested"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected nested dict to convert to map[string]interface{}, got %T", out["nested"])
+	}
+	if nested["port"] != int64(7777) {
+		t.Fatalf("expected nested port == 7777, got %v", nested["port"])
+	}
+
+	items, ok := out["items"].([]interface{})
+	if !ok || len(items) != 2 || items[0] != "a" || items[1] != "b" {
+		t.Fatalf("expected items == [\"a\", \"b\"], got %v", out["items"])
+	}
+}
+
+func writeTempFile(t *testing.T, name, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	return path
+}
+
+func TestEvalStarlark_ReturnsMainDictAsJSON(t *testing.T) {
+	file := writeTempFile(t, "samp.star", `
+def main(ctx):
+    return {"hostname": "from starlark", "os": ctx["os"], "port": 7777}
+`)
+
+	contents, err := EvalStarlark(file, Context{OS: "linux", Arch: "amd64", Env: map[string]string{}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(contents, &out); err != nil {
+		t.Fatalf("failed to unmarshal result: %v", err)
+	}
+
+	if out["hostname"] != "from starlark" || out["os"] != "linux" || out["port"].(float64) != 7777 {
+		t.Fatalf("unexpected result: %v", out)
+	}
+}
+
+func TestEvalStarlark_MissingMainFunctionErrors(t *testing.T) {
+	file := writeTempFile(t, "samp.star", `x = 1`)
+
+	_, err := EvalStarlark(file, NewContext())
+	if err == nil {
+		t.Fatalf("expected an error for a file with no main(ctx) function")
+	}
+}
+
+func TestEvalStarlark_NonDictReturnErrors(t *testing.T) {
+	file := writeTempFile(t, "samp.star", `
+def main(ctx):
+    return "not a dict"
+`)
+
+	_, err := EvalStarlark(file, NewContext())
+	if err == nil {
+		t.Fatalf("expected an error when main(ctx) does not return a dict")
+	}
+}
+
+func TestEvalJsonnet_ReturnsEvaluatedDocumentAsJSON(t *testing.T) {
+	file := writeTempFile(t, "samp.jsonnet", `
+function(os, arch, env) {
+	hostname: "from jsonnet",
+	os: os,
+	port: 7777,
+}
+`)
+
+	contents, err := EvalJsonnet(file, Context{OS: "linux", Arch: "amd64", Env: map[string]string{}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(contents, &out); err != nil {
+		t.Fatalf("failed to unmarshal result: %v", err)
+	}
+
+	if out["hostname"] != "from jsonnet" || out["os"] != "linux" || out["port"].(float64) != 7777 {
+		t.Fatalf("unexpected result: %v", out)
+	}
+}
+
+func TestEvalJsonnet_InvalidFileErrors(t *testing.T) {
+	file := writeTempFile(t, "samp.jsonnet", `this is not valid jsonnet {{{`)
+
+	_, err := EvalJsonnet(file, NewContext())
+	if err == nil {
+		t.Fatalf("expected an error for an invalid jsonnet file")
+	}
+}
@@ -0,0 +1,170 @@
+package runtime
+
+// appendSentinel is used as the first element of a Gamemodes/Filterscripts/Plugins slice in an
+// overlay config to mean "append these to the base list" instead of the default "replace it".
+const appendSentinel = "+"
+
+// MergeConfigs layers overlay on top of base and returns the result: pointer fields in overlay
+// replace the corresponding base field when non-nil (a nil pointer means "not set, keep base"),
+// and slice fields (Gamemodes, Filterscripts, Plugins) replace the base slice unless their first
+// element is the "+" sentinel, in which case the remaining elements are appended to base instead.
+func MergeConfigs(base, overlay Config) (merged Config) {
+	merged = base
+
+	if overlay.Version != nil {
+		merged.Version = overlay.Version
+	}
+	if overlay.Endpoint != nil {
+		merged.Endpoint = overlay.Endpoint
+	}
+	if overlay.Echo != nil {
+		merged.Echo = overlay.Echo
+	}
+	if overlay.RCONPassword != nil {
+		merged.RCONPassword = overlay.RCONPassword
+	}
+	if overlay.Port != nil {
+		merged.Port = overlay.Port
+	}
+	if overlay.Hostname != nil {
+		merged.Hostname = overlay.Hostname
+	}
+	if overlay.MaxPlayers != nil {
+		merged.MaxPlayers = overlay.MaxPlayers
+	}
+	if overlay.Language != nil {
+		merged.Language = overlay.Language
+	}
+	if overlay.Mapname != nil {
+		merged.Mapname = overlay.Mapname
+	}
+	if overlay.Weburl != nil {
+		merged.Weburl = overlay.Weburl
+	}
+	if overlay.GamemodeText != nil {
+		merged.GamemodeText = overlay.GamemodeText
+	}
+	if overlay.Bind != nil {
+		merged.Bind = overlay.Bind
+	}
+	if overlay.Password != nil {
+		merged.Password = overlay.Password
+	}
+	if overlay.Announce != nil {
+		merged.Announce = overlay.Announce
+	}
+	if overlay.LANMode != nil {
+		merged.LANMode = overlay.LANMode
+	}
+	if overlay.Query != nil {
+		merged.Query = overlay.Query
+	}
+	if overlay.RCON != nil {
+		merged.RCON = overlay.RCON
+	}
+	if overlay.LogQueries != nil {
+		merged.LogQueries = overlay.LogQueries
+	}
+	if overlay.Sleep != nil {
+		merged.Sleep = overlay.Sleep
+	}
+	if overlay.MaxNPC != nil {
+		merged.MaxNPC = overlay.MaxNPC
+	}
+	if overlay.StreamRate != nil {
+		merged.StreamRate = overlay.StreamRate
+	}
+	if overlay.StreamDistance != nil {
+		merged.StreamDistance = overlay.StreamDistance
+	}
+	if overlay.OnFootRate != nil {
+		merged.OnFootRate = overlay.OnFootRate
+	}
+	if overlay.InCarRate != nil {
+		merged.InCarRate = overlay.InCarRate
+	}
+	if overlay.WeaponRate != nil {
+		merged.WeaponRate = overlay.WeaponRate
+	}
+	if overlay.ChatLogging != nil {
+		merged.ChatLogging = overlay.ChatLogging
+	}
+	if overlay.Timestamp != nil {
+		merged.Timestamp = overlay.Timestamp
+	}
+	if overlay.NoSign != nil {
+		merged.NoSign = overlay.NoSign
+	}
+	if overlay.LogTimeFormat != nil {
+		merged.LogTimeFormat = overlay.LogTimeFormat
+	}
+	if overlay.MessageHoleLimit != nil {
+		merged.MessageHoleLimit = overlay.MessageHoleLimit
+	}
+	if overlay.MessagesLimit != nil {
+		merged.MessagesLimit = overlay.MessagesLimit
+	}
+	if overlay.AcksLimit != nil {
+		merged.AcksLimit = overlay.AcksLimit
+	}
+	if overlay.PlayerTimeout != nil {
+		merged.PlayerTimeout = overlay.PlayerTimeout
+	}
+	if overlay.MinConnectionTime != nil {
+		merged.MinConnectionTime = overlay.MinConnectionTime
+	}
+	if overlay.LagCompmode != nil {
+		merged.LagCompmode = overlay.LagCompmode
+	}
+	if overlay.ConnseedTime != nil {
+		merged.ConnseedTime = overlay.ConnseedTime
+	}
+	if overlay.DBLogging != nil {
+		merged.DBLogging = overlay.DBLogging
+	}
+	if overlay.DBLogQueries != nil {
+		merged.DBLogQueries = overlay.DBLogQueries
+	}
+	if overlay.ConnectCookies != nil {
+		merged.ConnectCookies = overlay.ConnectCookies
+	}
+	if overlay.CookieLogging != nil {
+		merged.CookieLogging = overlay.CookieLogging
+	}
+	if overlay.Output != nil {
+		merged.Output = overlay.Output
+	}
+
+	merged.Gamemodes = mergeStringSlice(base.Gamemodes, overlay.Gamemodes)
+	merged.Filterscripts = mergeStringSlice(base.Filterscripts, overlay.Filterscripts)
+	merged.Plugins = mergePluginSlice(base.Plugins, overlay.Plugins)
+
+	return
+}
+
+// mergeStringSlice replaces base with overlay, unless overlay's first element is the append
+// sentinel, in which case the rest of overlay is appended to base. A nil/empty overlay keeps base.
+func mergeStringSlice(base, overlay []string) []string {
+	if len(overlay) == 0 {
+		return base
+	}
+
+	if overlay[0] == appendSentinel {
+		return append(append([]string{}, base...), overlay[1:]...)
+	}
+
+	return overlay
+}
+
+// mergePluginSlice is the []Plugin equivalent of mergeStringSlice.
+func mergePluginSlice(base, overlay []Plugin) []Plugin {
+	if len(overlay) == 0 {
+		return base
+	}
+
+	if string(overlay[0]) == appendSentinel {
+		return append(append([]Plugin{}, base...), overlay[1:]...)
+	}
+
+	return overlay
+}
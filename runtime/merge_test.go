@@ -0,0 +1,78 @@
+package runtime
+
+import "testing"
+
+func intPtr(i int) *int       { return &i }
+func strPtr(s string) *string { return &s }
+
+func TestMergeConfigs_NilPointerKeepsBase(t *testing.T) {
+	base := Config{Port: intPtr(8192)}
+	overlay := Config{} // Port left nil, meaning "not set" in the overlay
+
+	merged := MergeConfigs(base, overlay)
+
+	if merged.Port == nil || *merged.Port != 8192 {
+		t.Fatalf("expected base port 8192 to survive a nil overlay pointer, got %v", merged.Port)
+	}
+}
+
+func TestMergeConfigs_ZeroPointerReplacesBase(t *testing.T) {
+	base := Config{Port: intPtr(8192)}
+	overlay := Config{Port: intPtr(0)} // explicitly set to the zero value
+
+	merged := MergeConfigs(base, overlay)
+
+	if merged.Port == nil || *merged.Port != 0 {
+		t.Fatalf("expected overlay's explicit zero value to replace base, got %v", merged.Port)
+	}
+}
+
+func TestMergeConfigs_StringPointerReplace(t *testing.T) {
+	base := Config{Hostname: strPtr("base server")}
+	overlay := Config{Hostname: strPtr("dev server")}
+
+	merged := MergeConfigs(base, overlay)
+
+	if merged.Hostname == nil || *merged.Hostname != "dev server" {
+		t.Fatalf("expected overlay hostname to replace base, got %v", merged.Hostname)
+	}
+}
+
+func TestMergeConfigs_SliceReplace(t *testing.T) {
+	base := Config{Gamemodes: []string{"rivershell"}}
+	overlay := Config{Gamemodes: []string{"ctf"}}
+
+	merged := MergeConfigs(base, overlay)
+
+	if len(merged.Gamemodes) != 1 || merged.Gamemodes[0] != "ctf" {
+		t.Fatalf("expected overlay gamemodes to replace base, got %v", merged.Gamemodes)
+	}
+}
+
+func TestMergeConfigs_SliceAppendSentinel(t *testing.T) {
+	base := Config{Gamemodes: []string{"rivershell"}}
+	overlay := Config{Gamemodes: []string{"+", "ctf"}}
+
+	merged := MergeConfigs(base, overlay)
+
+	expected := []string{"rivershell", "ctf"}
+	if len(merged.Gamemodes) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, merged.Gamemodes)
+	}
+	for i, g := range expected {
+		if merged.Gamemodes[i] != g {
+			t.Fatalf("expected %v, got %v", expected, merged.Gamemodes)
+		}
+	}
+}
+
+func TestMergeConfigs_EmptyOverlaySliceKeepsBase(t *testing.T) {
+	base := Config{Filterscripts: []string{"admin"}}
+	overlay := Config{}
+
+	merged := MergeConfigs(base, overlay)
+
+	if len(merged.Filterscripts) != 1 || merged.Filterscripts[0] != "admin" {
+		t.Fatalf("expected base filterscripts to survive an empty overlay slice, got %v", merged.Filterscripts)
+	}
+}
@@ -0,0 +1,114 @@
+package runtime
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RCONClient sends administration commands to a running server via the SA:MP RCON protocol.
+type RCONClient interface {
+	Send(command string) (response string, err error)
+}
+
+// rconClient is the default RCONClient implementation, talking the SA:MP UDP RCON protocol
+// directly to a running server process.
+type rconClient struct {
+	addr     *net.UDPAddr
+	password string
+	timeout  time.Duration
+}
+
+// NewRCONClient creates an RCONClient targeting the given host:port using the server's
+// configured RCON password.
+func NewRCONClient(hostport string, password string) (client RCONClient, err error) {
+	addr, err := net.ResolveUDPAddr("udp4", hostport)
+	if err != nil {
+		err = errors.Wrap(err, "failed to resolve rcon address")
+		return
+	}
+
+	client = &rconClient{addr: addr, password: password, timeout: 3 * time.Second}
+
+	return
+}
+
+// Send issues a single RCON command and returns the server's text response, following the
+// SA:MP "SAMP" UDP packet format used for admin commands.
+func (c *rconClient) Send(command string) (response string, err error) {
+	conn, err := net.DialUDP("udp4", nil, c.addr)
+	if err != nil {
+		err = errors.Wrap(err, "failed to dial rcon address")
+		return
+	}
+	defer conn.Close() // nolint:errcheck
+
+	if setErr := conn.SetDeadline(time.Now().Add(c.timeout)); setErr != nil {
+		err = errors.Wrap(setErr, "failed to set rcon deadline")
+		return
+	}
+
+	packet, err := buildRCONPacket(c.addr, c.password, command)
+	if err != nil {
+		err = errors.Wrap(err, "failed to build rcon packet")
+		return
+	}
+
+	if _, werr := conn.Write(packet); werr != nil {
+		err = errors.Wrap(werr, "failed to send rcon packet")
+		return
+	}
+
+	buf := make([]byte, 4096)
+	n, _, rerr := conn.ReadFromUDP(buf)
+	if rerr != nil {
+		err = errors.Wrap(rerr, "failed to read rcon response")
+		return
+	}
+
+	// header is "SAMP"(4) + ip(4) + port(2) + 'x'(1) + length(2), response text follows at 13
+	if n > 13 {
+		response = string(buf[13:n])
+	}
+
+	return
+}
+
+func buildRCONPacket(addr *net.UDPAddr, password string, command string) (packet []byte, err error) {
+	var buf bytes.Buffer
+
+	buf.WriteString("SAMP")
+
+	ip4 := addr.IP.To4()
+	if ip4 == nil {
+		err = errors.New("rcon address must be IPv4")
+		return
+	}
+	buf.Write(ip4)
+
+	if werr := binary.Write(&buf, binary.LittleEndian, uint16(addr.Port)); werr != nil {
+		err = werr
+		return
+	}
+
+	buf.WriteByte('x')
+
+	if werr := binary.Write(&buf, binary.LittleEndian, uint16(len(password))); werr != nil {
+		err = werr
+		return
+	}
+	buf.WriteString(password)
+
+	if werr := binary.Write(&buf, binary.LittleEndian, uint16(len(command))); werr != nil {
+		err = werr
+		return
+	}
+	buf.WriteString(command)
+
+	packet = buf.Bytes()
+
+	return
+}
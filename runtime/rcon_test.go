@@ -0,0 +1,103 @@
+package runtime
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+)
+
+func TestBuildRCONPacket_Layout(t *testing.T) {
+	addr := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 7777}
+
+	packet, err := buildRCONPacket(addr, "secret", "gmx")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// "SAMP"(4) + ip(4) + port(2) + 'x'(1) + passwordLen(2) + password + cmdLen(2) + command
+	if string(packet[0:4]) != "SAMP" {
+		t.Fatalf("expected packet to start with 'SAMP', got %q", packet[0:4])
+	}
+	if !net.IP(packet[4:8]).Equal(net.IPv4(127, 0, 0, 1).To4()) {
+		t.Fatalf("expected ip bytes 4:8 to be 127.0.0.1, got %v", packet[4:8])
+	}
+	if port := binary.LittleEndian.Uint16(packet[8:10]); port != 7777 {
+		t.Fatalf("expected port bytes 8:10 to be 7777, got %d", port)
+	}
+	if packet[10] != 'x' {
+		t.Fatalf("expected byte 10 to be 'x', got %q", packet[10])
+	}
+
+	pwLen := binary.LittleEndian.Uint16(packet[11:13])
+	if int(pwLen) != len("secret") {
+		t.Fatalf("expected password length %d at bytes 11:13, got %d", len("secret"), pwLen)
+	}
+	pwStart := 13
+	pwEnd := pwStart + int(pwLen)
+	if string(packet[pwStart:pwEnd]) != "secret" {
+		t.Fatalf("expected password 'secret' at bytes %d:%d, got %q", pwStart, pwEnd, packet[pwStart:pwEnd])
+	}
+
+	cmdLen := binary.LittleEndian.Uint16(packet[pwEnd : pwEnd+2])
+	if int(cmdLen) != len("gmx") {
+		t.Fatalf("expected command length %d, got %d", len("gmx"), cmdLen)
+	}
+	cmdStart := pwEnd + 2
+	if string(packet[cmdStart:cmdStart+int(cmdLen)]) != "gmx" {
+		t.Fatalf("expected command 'gmx', got %q", packet[cmdStart:cmdStart+int(cmdLen)])
+	}
+	if len(packet) != cmdStart+int(cmdLen) {
+		t.Fatalf("expected packet to end right after the command, got length %d", len(packet))
+	}
+}
+
+func TestBuildRCONPacket_RejectsNonIPv4Address(t *testing.T) {
+	addr := &net.UDPAddr{IP: net.ParseIP("::1"), Port: 7777}
+
+	_, err := buildRCONPacket(addr, "secret", "gmx")
+	if err == nil {
+		t.Fatalf("expected an error for a non-IPv4 address")
+	}
+}
+
+func TestSend_ParsesResponseAtFixedOffset(t *testing.T) {
+	server, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 0})
+	if err != nil {
+		t.Fatalf("failed to start fake rcon server: %v", err)
+	}
+	defer server.Close() // nolint:errcheck
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buf := make([]byte, 4096)
+		_, clientAddr, rerr := server.ReadFromUDP(buf)
+		if rerr != nil {
+			return
+		}
+
+		// Echo back a response packet with the same 13-byte header the client expects, followed
+		// by the response text.
+		header, herr := buildRCONPacket(server.LocalAddr().(*net.UDPAddr), "", "")
+		if herr != nil {
+			return
+		}
+		resp := append(header[:13:13], []byte("pong")...)
+		_, _ = server.WriteToUDP(resp, clientAddr)
+	}()
+
+	client, err := NewRCONClient(server.LocalAddr().String(), "secret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	response, err := client.Send("ping")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-done
+
+	if response != "pong" {
+		t.Fatalf("expected response 'pong' read from byte offset 13, got %q", response)
+	}
+}
@@ -0,0 +1,129 @@
+package runtime
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// jsonSchema is a minimal subset of the JSON Schema (draft-07) vocabulary, just enough to give
+// editors completion and basic validation for samp.json.
+type jsonSchema struct {
+	Schema      string                `json:"$schema"`
+	Title       string                `json:"title"`
+	Type        string                `json:"type"`
+	Properties  map[string]jsonSchema `json:"properties,omitempty"`
+	Items       *jsonSchema           `json:"items,omitempty"`
+	Required    []string              `json:"required,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Default     interface{}           `json:"default,omitempty"`
+	Minimum     *int                  `json:"minimum,omitempty"`
+	Maximum     *int                  `json:"maximum,omitempty"`
+}
+
+// GenerateSchema writes a JSON Schema document derived from Config's struct tags to w, so editors
+// can offer completion and validation for samp.json files.
+func GenerateSchema(w io.Writer) (err error) {
+	schema := jsonSchema{
+		Schema:     "http://json-schema.org/draft-07/schema#",
+		Title:      "samp.json",
+		Type:       "object",
+		Properties: map[string]jsonSchema{},
+	}
+
+	t := reflect.TypeOf(Config{})
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		jsonTag := field.Tag.Get("json")
+		if jsonTag == "" || jsonTag == "-" {
+			continue
+		}
+		name := strings.Split(jsonTag, ",")[0]
+
+		propType := jsonType(field.Type)
+		prop := jsonSchema{Type: propType}
+
+		if propType == "array" {
+			prop.Items = &jsonSchema{Type: jsonType(field.Type.Elem())}
+		}
+
+		if def := field.Tag.Get("default"); def != "" && def != "-" {
+			prop.Default = defaultValue(propType, def)
+		}
+
+		if name == "port" {
+			min, max := 1, 65535
+			prop.Minimum = &min
+			prop.Maximum = &max
+		}
+		if name == "maxplayers" {
+			max := 1000
+			prop.Maximum = &max
+		}
+
+		schema.Properties[name] = prop
+
+		if field.Tag.Get("required") == "1" {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(schema); err != nil {
+		err = errors.Wrap(err, "failed to encode schema")
+	}
+
+	return
+}
+
+// defaultValue converts a `default` struct tag's raw string into the Go value matching propType,
+// so the generated schema's "default" has the same JSON type as the field it describes, e.g.
+// "8192" becomes the number 8192 rather than the string "8192". Falls back to the raw string if
+// it can't be parsed as propType.
+func defaultValue(propType, raw string) interface{} {
+	switch propType {
+	case "integer":
+		if v, err := strconv.Atoi(raw); err == nil {
+			return v
+		}
+	case "number":
+		if v, err := strconv.ParseFloat(raw, 64); err == nil {
+			return v
+		}
+	case "boolean":
+		if v, err := strconv.ParseBool(raw); err == nil {
+			return v
+		}
+	}
+
+	return raw
+}
+
+// jsonType maps a Config field's Go type to the corresponding JSON Schema primitive type.
+func jsonType(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice:
+		return "array"
+	default:
+		return "object"
+	}
+}
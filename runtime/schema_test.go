@@ -0,0 +1,94 @@
+package runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func generatedProperties(t *testing.T) map[string]map[string]interface{} {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := GenerateSchema(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("failed to unmarshal generated schema: %v", err)
+	}
+
+	props, ok := doc["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a properties object, got %v", doc["properties"])
+	}
+
+	out := make(map[string]map[string]interface{}, len(props))
+	for name, raw := range props {
+		out[name] = raw.(map[string]interface{})
+	}
+	return out
+}
+
+func TestGenerateSchema_IntegerDefaultIsANumber(t *testing.T) {
+	port := generatedProperties(t)["port"]
+
+	if port["type"] != "integer" {
+		t.Fatalf("expected port type 'integer', got %v", port["type"])
+	}
+	if _, ok := port["default"].(float64); !ok {
+		t.Fatalf("expected port default to unmarshal as a JSON number, got %T (%v)", port["default"], port["default"])
+	}
+	if port["default"].(float64) != 8192 {
+		t.Fatalf("expected port default 8192, got %v", port["default"])
+	}
+}
+
+func TestGenerateSchema_BooleanDefaultIsABool(t *testing.T) {
+	announce := generatedProperties(t)["announce"]
+
+	if announce["type"] != "boolean" {
+		t.Fatalf("expected announce type 'boolean', got %v", announce["type"])
+	}
+	if announce["default"] != true {
+		t.Fatalf("expected announce default true, got %#v", announce["default"])
+	}
+}
+
+func TestGenerateSchema_NumberDefaultIsAFloat(t *testing.T) {
+	streamDistance := generatedProperties(t)["stream_distance"]
+
+	if streamDistance["type"] != "number" {
+		t.Fatalf("expected stream_distance type 'number', got %v", streamDistance["type"])
+	}
+	if streamDistance["default"] != 200.0 {
+		t.Fatalf("expected stream_distance default 200.0, got %v", streamDistance["default"])
+	}
+}
+
+func TestGenerateSchema_StringDefaultIsAString(t *testing.T) {
+	hostname := generatedProperties(t)["hostname"]
+
+	if hostname["default"] != "SA-MP Server" {
+		t.Fatalf("expected hostname default 'SA-MP Server', got %v", hostname["default"])
+	}
+}
+
+func TestGenerateSchema_ArrayFieldsHaveItemSchema(t *testing.T) {
+	props := generatedProperties(t)
+
+	for name, itemType := range map[string]string{
+		"gamemodes":     "string",
+		"filterscripts": "string",
+		"plugins":       "string",
+	} {
+		items, ok := props[name]["items"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected %q to have an items schema, got %v", name, props[name]["items"])
+		}
+		if items["type"] != itemType {
+			t.Fatalf("expected %q items type %q, got %v", name, itemType, items["type"])
+		}
+	}
+}
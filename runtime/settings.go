@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/Southclaws/sampctl/runtime/dynconfig"
 	"github.com/Southclaws/sampctl/util"
 	"github.com/ghodss/yaml"
 	"github.com/pkg/errors"
@@ -94,19 +95,88 @@ func NewConfigFromEnvironment(dir string) (cfg Config, err error) {
 	return
 }
 
-// ConfigFromDirectory creates a config from a directory by searching for a JSON or YAML file to
-// read settings from. If both exist, the JSON file takes precedence.
+// ConfigFromDirectory creates a config from a directory by searching for a JSON, YAML, Jsonnet or
+// Starlark file to read settings from, in that order of precedence: samp.json, samp.yaml,
+// samp.jsonnet, samp.star. If SAMPCTL_PROFILE is set and a matching samp.<profile>.json overlay
+// exists in dir, it is layered on top of the base config via MergeConfigs.
 func ConfigFromDirectory(dir string) (cfg Config, err error) {
+	return ConfigFromDirectoryWithProfile(dir, os.Getenv("SAMPCTL_PROFILE"))
+}
+
+// ConfigFromDirectoryWithProfile is ConfigFromDirectory with the profile selected explicitly
+// (e.g. from a --profile flag) rather than read from SAMPCTL_PROFILE. An empty profile skips
+// overlay merging entirely.
+func ConfigFromDirectoryWithProfile(dir string, profile string) (cfg Config, err error) {
 	jsonFile := filepath.Join(dir, "samp.json")
-	if util.Exists(jsonFile) {
+	yamlFile := filepath.Join(dir, "samp.yaml")
+	jsonnetFile := filepath.Join(dir, "samp.jsonnet")
+	starFile := filepath.Join(dir, "samp.star")
+
+	switch {
+	case util.Exists(jsonFile):
 		cfg, err = ConfigFromJSON(jsonFile)
-	} else {
-		yamlFile := filepath.Join(dir, "samp.yaml")
-		if util.Exists(yamlFile) {
-			cfg, err = ConfigFromYAML(yamlFile)
-		} else {
-			err = errors.New("directory does not contain a samp.json or samp.yaml file")
-		}
+	case util.Exists(yamlFile):
+		cfg, err = ConfigFromYAML(yamlFile)
+	case util.Exists(jsonnetFile):
+		cfg, err = ConfigFromJsonnet(jsonnetFile)
+	case util.Exists(starFile):
+		cfg, err = ConfigFromStarlark(starFile)
+	default:
+		err = errors.New("directory does not contain a samp.json, samp.yaml, samp.jsonnet or samp.star file")
+	}
+	if err != nil {
+		return
+	}
+
+	if profile == "" {
+		return
+	}
+
+	overlayFile := filepath.Join(dir, fmt.Sprintf("samp.%s.json", profile))
+	if !util.Exists(overlayFile) {
+		return
+	}
+
+	overlay, err := ConfigFromJSON(overlayFile)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to load profile overlay '%s'", profile)
+		return
+	}
+
+	cfg = MergeConfigs(cfg, overlay)
+
+	return
+}
+
+// ConfigFromJsonnet evaluates a Jsonnet file into a Config, passing OS/arch/env context as
+// top-level arguments so the script can branch on the platform it's running on.
+func ConfigFromJsonnet(file string) (cfg Config, err error) {
+	contents, err := dynconfig.EvalJsonnet(file, dynconfig.NewContext())
+	if err != nil {
+		err = errors.Wrap(err, "failed to evaluate samp.jsonnet")
+		return
+	}
+
+	err = json.Unmarshal(contents, &cfg)
+	if err != nil {
+		err = errors.Wrap(err, "failed to unmarshal samp.jsonnet result")
+	}
+
+	return
+}
+
+// ConfigFromStarlark evaluates a Starlark file's main(ctx) function into a Config, passing
+// OS/arch/env context so the script can branch on the platform it's running on.
+func ConfigFromStarlark(file string) (cfg Config, err error) {
+	contents, err := dynconfig.EvalStarlark(file, dynconfig.NewContext())
+	if err != nil {
+		err = errors.Wrap(err, "failed to evaluate samp.star")
+		return
+	}
+
+	err = json.Unmarshal(contents, &cfg)
+	if err != nil {
+		err = errors.Wrap(err, "failed to unmarshal samp.star result")
 	}
 
 	return
@@ -178,8 +248,19 @@ func (cfg *Config) LoadEnvironmentVariables() {
 			fieldval.Elem().SetString(value)
 
 		case "[]string":
-			// todo: allow filterscripts and plugins via env vars
-			fmt.Println("cannot set gamemode via environment variables yet")
+			if stype.Name == "Gamemodes" {
+				fieldval.Set(reflect.ValueOf(parseGamemodesEnv(value)))
+			} else {
+				fieldval.Set(reflect.ValueOf(strings.Split(value, ",")))
+			}
+
+		case "[]runtime.Plugin":
+			parts := strings.Split(value, ",")
+			plugins := make([]Plugin, len(parts))
+			for i, p := range parts {
+				plugins[i] = Plugin(p)
+			}
+			fieldval.Set(reflect.ValueOf(plugins))
 
 		case "*bool":
 			valueAsBool, err := strconv.ParseBool(value)
@@ -220,3 +301,96 @@ func (cfg *Config) LoadEnvironmentVariables() {
 		}
 	}
 }
+
+// parseGamemodesEnv parses a SAMP_GAMEMODES value of the form "name:repeats,name:repeats,..."
+// into a flat []string, where ":repeats" is optional and defaults to 1, e.g.
+// "rivershell:1,ctf:2" becomes ["rivershell", "ctf", "ctf"].
+func parseGamemodesEnv(value string) (gamemodes []string) {
+	for _, entry := range strings.Split(value, ",") {
+		name := entry
+		repeats := 1
+
+		if idx := strings.LastIndex(entry, ":"); idx != -1 {
+			name = entry[:idx]
+			if n, err := strconv.Atoi(entry[idx+1:]); err == nil {
+				repeats = n
+			}
+		}
+
+		for i := 0; i < repeats; i++ {
+			gamemodes = append(gamemodes, name)
+		}
+	}
+
+	return
+}
+
+// EnvironmentVariables returns the full set of SAMP_* environment variables that represent this
+// Config, the inverse of LoadEnvironmentVariables. Useful for headless, env-var-driven
+// deployments such as Docker or Kubernetes manifests where no samp.json is written to disk.
+func (cfg *Config) EnvironmentVariables() map[string]string {
+	out := make(map[string]string)
+
+	v := reflect.ValueOf(cfg).Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		fieldval := v.Field(i)
+		stype := t.Field(i)
+
+		jsonTag := strings.Split(stype.Tag.Get("json"), ",")[0]
+		if jsonTag == "" || jsonTag == "-" {
+			continue
+		}
+		name := "SAMP_" + strings.ToUpper(jsonTag)
+
+		switch stype.Type.String() {
+		case "*string", "*bool", "*int", "*float32":
+			if fieldval.IsNil() {
+				continue
+			}
+			out[name] = fmt.Sprintf("%v", fieldval.Elem().Interface())
+
+		case "[]string":
+			if fieldval.Len() == 0 {
+				continue
+			}
+			if stype.Name == "Gamemodes" {
+				out[name] = formatGamemodesEnv(fieldval.Interface().([]string))
+			} else {
+				out[name] = strings.Join(fieldval.Interface().([]string), ",")
+			}
+
+		case "[]runtime.Plugin":
+			if fieldval.Len() == 0 {
+				continue
+			}
+			plugins := fieldval.Interface().([]Plugin)
+			names := make([]string, len(plugins))
+			for i, p := range plugins {
+				names[i] = string(p)
+			}
+			out[name] = strings.Join(names, ",")
+		}
+	}
+
+	return out
+}
+
+// formatGamemodesEnv collapses consecutive repeated gamemode names into "name:repeats" entries,
+// the inverse of parseGamemodesEnv.
+func formatGamemodesEnv(gamemodes []string) string {
+	var entries []string
+
+	for i := 0; i < len(gamemodes); {
+		name := gamemodes[i]
+		repeats := 1
+		for i+repeats < len(gamemodes) && gamemodes[i+repeats] == name {
+			repeats++
+		}
+		entries = append(entries, fmt.Sprintf("%s:%d", name, repeats))
+		i += repeats
+	}
+
+	return strings.Join(entries, ",")
+}
@@ -0,0 +1,146 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseGamemodesEnv_ExpandsRepeatsIntoFlatSlice(t *testing.T) {
+	got := parseGamemodesEnv("rivershell:1,ctf:2")
+	expected := []string{"rivershell", "ctf", "ctf"}
+
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i, g := range expected {
+		if got[i] != g {
+			t.Fatalf("expected %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestParseGamemodesEnv_MissingRepeatsDefaultsToOne(t *testing.T) {
+	got := parseGamemodesEnv("rivershell")
+	expected := []string{"rivershell"}
+
+	if len(got) != 1 || got[0] != expected[0] {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestParseGamemodesEnv_TrailingColonDefaultsToOne(t *testing.T) {
+	got := parseGamemodesEnv("rivershell:")
+	expected := []string{"rivershell"}
+
+	if len(got) != 1 || got[0] != expected[0] {
+		t.Fatalf("expected a trailing colon with no count to default to 1 repeat, got %v", got)
+	}
+}
+
+func TestFormatGamemodesEnv_CollapsesNonConsecutiveDuplicatesSeparately(t *testing.T) {
+	// "ctf" appears twice but not back-to-back, so it must be emitted as two separate ":1"
+	// entries rather than folded into one ":2" entry.
+	got := formatGamemodesEnv([]string{"ctf", "rivershell", "ctf"})
+	expected := "ctf:1,rivershell:1,ctf:1"
+
+	if got != expected {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestGamemodesEnv_RoundTrip(t *testing.T) {
+	original := "rivershell:1,ctf:2"
+
+	gamemodes := parseGamemodesEnv(original)
+	roundTripped := formatGamemodesEnv(gamemodes)
+
+	if roundTripped != original {
+		t.Fatalf("expected round trip to reproduce %q, got %q (via %v)", original, roundTripped, gamemodes)
+	}
+}
+
+func TestEnvironmentVariables_PopulatesFromConfig(t *testing.T) {
+	cfg := Config{
+		Hostname:  strPtr("my server"),
+		Port:      intPtr(7777),
+		Announce:  boolPtr(true),
+		Gamemodes: []string{"rivershell", "ctf", "ctf"},
+		Plugins:   []Plugin{"crashdetect", "streamer"},
+	}
+
+	vars := cfg.EnvironmentVariables()
+
+	if vars["SAMP_HOSTNAME"] != "my server" {
+		t.Fatalf("expected SAMP_HOSTNAME='my server', got %v", vars["SAMP_HOSTNAME"])
+	}
+	if vars["SAMP_PORT"] != "7777" {
+		t.Fatalf("expected SAMP_PORT='7777', got %v", vars["SAMP_PORT"])
+	}
+	if vars["SAMP_ANNOUNCE"] != "true" {
+		t.Fatalf("expected SAMP_ANNOUNCE='true', got %v", vars["SAMP_ANNOUNCE"])
+	}
+	if vars["SAMP_GAMEMODES"] != "rivershell:1,ctf:2" {
+		t.Fatalf("expected SAMP_GAMEMODES='rivershell:1,ctf:2', got %v", vars["SAMP_GAMEMODES"])
+	}
+	if vars["SAMP_PLUGINS"] != "crashdetect,streamer" {
+		t.Fatalf("expected SAMP_PLUGINS='crashdetect,streamer', got %v", vars["SAMP_PLUGINS"])
+	}
+}
+
+func TestEnvironmentVariables_OmitsUnsetAndEmptyFields(t *testing.T) {
+	cfg := Config{Hostname: strPtr("my server")}
+
+	vars := cfg.EnvironmentVariables()
+
+	if _, present := vars["SAMP_PORT"]; present {
+		t.Fatalf("expected nil Port to be omitted, got %v", vars["SAMP_PORT"])
+	}
+	if _, present := vars["SAMP_GAMEMODES"]; present {
+		t.Fatalf("expected empty Gamemodes to be omitted, got %v", vars["SAMP_GAMEMODES"])
+	}
+}
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestConfigFromStarlark_EvaluatesMainIntoConfig(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "samp.star")
+	script := "def main(ctx):\n    return {\"hostname\": \"from starlark\", \"port\": 7777}\n"
+	if err := os.WriteFile(file, []byte(script), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg, err := ConfigFromStarlark(file)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Hostname == nil || *cfg.Hostname != "from starlark" {
+		t.Fatalf("expected hostname 'from starlark', got %v", cfg.Hostname)
+	}
+	if cfg.Port == nil || *cfg.Port != 7777 {
+		t.Fatalf("expected port 7777, got %v", cfg.Port)
+	}
+}
+
+func TestConfigFromJsonnet_EvaluatesFileIntoConfig(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "samp.jsonnet")
+	script := "function(os, arch, env) {\n\thostname: \"from jsonnet\",\n\tport: 7777,\n}\n"
+	if err := os.WriteFile(file, []byte(script), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg, err := ConfigFromJsonnet(file)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Hostname == nil || *cfg.Hostname != "from jsonnet" {
+		t.Fatalf("expected hostname 'from jsonnet', got %v", cfg.Hostname)
+	}
+	if cfg.Port == nil || *cfg.Port != 7777 {
+		t.Fatalf("expected port 7777, got %v", cfg.Port)
+	}
+}
@@ -0,0 +1,126 @@
+package runtime
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/Southclaws/sampctl/util"
+)
+
+// ValidationError is returned by Config.Validate and carries one Violation per invalid field so
+// callers (editors, `sampctl package build`) can present them inline rather than as a single
+// opaque error string.
+type ValidationError struct {
+	Violations []Violation
+}
+
+// Violation describes a single field that failed validation.
+type Violation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface by joining all violations into one line.
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = fmt.Sprintf("%s: %s", v.Field, v.Message)
+	}
+	return fmt.Sprintf("invalid config: %s", strings.Join(parts, "; "))
+}
+
+// add appends a violation, used internally while building up a ValidationError.
+func (e *ValidationError) add(field, message string) {
+	e.Violations = append(e.Violations, Violation{Field: field, Message: message})
+}
+
+// Validate performs cross-field and semantic validation on top of the `required`/`default`
+// struct tags already applied during loading. It returns a *ValidationError (never a bare error)
+// so callers can range over individual field violations, or nil if the config is valid.
+func (cfg *Config) Validate() error {
+	verr := &ValidationError{}
+
+	if cfg.Port != nil && (*cfg.Port < 1 || *cfg.Port > 65535) {
+		verr.add("port", fmt.Sprintf("must be between 1 and 65535, got %d", *cfg.Port))
+	}
+
+	if cfg.RCONPassword != nil {
+		switch strings.ToLower(*cfg.RCONPassword) {
+		case "":
+			verr.add("rcon_password", "must not be empty")
+		case "changeme":
+			verr.add("rcon_password", "must be changed from the default value 'changeme'")
+		}
+	}
+
+	if cfg.MaxPlayers != nil && *cfg.MaxPlayers > 1000 {
+		verr.add("maxplayers", fmt.Sprintf("must be <= 1000, got %d", *cfg.MaxPlayers))
+	}
+
+	for _, field := range []struct {
+		name  string
+		value *int
+	}{
+		{"stream_rate", cfg.StreamRate},
+		{"onfoot_rate", cfg.OnFootRate},
+		{"incar_rate", cfg.InCarRate},
+		{"weapon_rate", cfg.WeaponRate},
+	} {
+		if field.value != nil && *field.value <= 0 {
+			verr.add(field.name, fmt.Sprintf("must be a positive rate, got %d", *field.value))
+		}
+	}
+
+	if cfg.Bind != nil && *cfg.Bind != "" {
+		if net.ParseIP(*cfg.Bind) == nil {
+			verr.add("bind", fmt.Sprintf("'%s' is not a parseable IP address", *cfg.Bind))
+		}
+	}
+
+	cfg.validateGamemodes(verr)
+	cfg.validatePlugins(verr)
+
+	if len(verr.Violations) == 0 {
+		return nil
+	}
+
+	return verr
+}
+
+// validateGamemodes checks that every configured gamemode has a matching .amx file in the
+// gamemodes/ subdirectory, when cfg is associated with a directory. Repeats are represented as
+// repeated elements (e.g. a gamemode played twice is ["rivershell", "rivershell"]), not a single
+// "name repeats" entry, so each element is already a bare gamemode name.
+func (cfg *Config) validateGamemodes(verr *ValidationError) {
+	if cfg.dir == nil {
+		return
+	}
+
+	for _, name := range cfg.Gamemodes {
+		path := filepath.Join(*cfg.dir, "gamemodes", name+".amx")
+		if !util.Exists(path) {
+			verr.add("gamemodes", fmt.Sprintf("gamemode '%s' has no matching file at %s", name, path))
+		}
+	}
+}
+
+// validatePlugins checks that every configured plugin resolves to a filename for the host OS.
+func (cfg *Config) validatePlugins(verr *ValidationError) {
+	ext := ".so"
+	if runtime.GOOS == "windows" {
+		ext = ".dll"
+	}
+
+	for _, p := range cfg.Plugins {
+		name := string(p)
+		if strings.HasSuffix(name, ".dll") && ext != ".dll" {
+			verr.add("plugins", fmt.Sprintf("plugin '%s' is a Windows binary, host OS is %s", name, runtime.GOOS))
+		}
+		if strings.HasSuffix(name, ".so") && ext != ".so" {
+			verr.add("plugins", fmt.Sprintf("plugin '%s' is a Linux binary, host OS is %s", name, runtime.GOOS))
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidate_PortOutOfRange(t *testing.T) {
+	cfg := Config{Port: intPtr(70000), RCONPassword: strPtr("topsecret")}
+
+	err := cfg.Validate()
+
+	assertViolation(t, err, "port")
+}
+
+func TestValidate_RCONPasswordDefaultRejected(t *testing.T) {
+	cfg := Config{Port: intPtr(7777), RCONPassword: strPtr("changeme")}
+
+	err := cfg.Validate()
+
+	assertViolation(t, err, "rcon_password")
+}
+
+func TestValidate_RCONPasswordEmptyRejected(t *testing.T) {
+	cfg := Config{Port: intPtr(7777), RCONPassword: strPtr("")}
+
+	err := cfg.Validate()
+
+	assertViolation(t, err, "rcon_password")
+}
+
+func TestValidate_MaxPlayersOverLimit(t *testing.T) {
+	cfg := Config{RCONPassword: strPtr("topsecret"), MaxPlayers: intPtr(1001)}
+
+	err := cfg.Validate()
+
+	assertViolation(t, err, "maxplayers")
+}
+
+func TestValidate_NonPositiveRateRejected(t *testing.T) {
+	cfg := Config{RCONPassword: strPtr("topsecret"), StreamRate: intPtr(0)}
+
+	err := cfg.Validate()
+
+	assertViolation(t, err, "stream_rate")
+}
+
+func TestValidate_UnparseableBindRejected(t *testing.T) {
+	cfg := Config{RCONPassword: strPtr("topsecret"), Bind: strPtr("not-an-ip")}
+
+	err := cfg.Validate()
+
+	assertViolation(t, err, "bind")
+}
+
+func TestValidate_ValidConfigHasNoViolations(t *testing.T) {
+	cfg := Config{RCONPassword: strPtr("topsecret")}
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected no violations, got %v", err)
+	}
+}
+
+func TestValidateGamemodes_RepeatedNameUsesFlatRepresentation(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "gamemodes"), 0o755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "gamemodes", "rivershell.amx"), nil, 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg := Config{
+		RCONPassword: strPtr("topsecret"),
+		// repeats are represented as repeated elements, not a single "name N" entry
+		Gamemodes: []string{"rivershell", "rivershell"},
+	}
+	cfg.dir = &dir
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected a repeated gamemode to resolve to the same file, got %v", err)
+	}
+}
+
+func TestValidateGamemodes_MissingFileRejected(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "gamemodes"), 0o755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg := Config{RCONPassword: strPtr("topsecret"), Gamemodes: []string{"missing"}}
+	cfg.dir = &dir
+
+	err := cfg.Validate()
+
+	assertViolation(t, err, "gamemodes")
+}
+
+func assertViolation(t *testing.T, err error, field string) {
+	t.Helper()
+
+	verr, ok := err.(*ValidationError)
+	if !ok || verr == nil {
+		t.Fatalf("expected a *ValidationError, got %v", err)
+	}
+
+	for _, v := range verr.Violations {
+		if v.Field == field {
+			return
+		}
+	}
+	t.Fatalf("expected a violation on field %q, got %+v", field, verr.Violations)
+}
@@ -0,0 +1,277 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+)
+
+// FieldAction describes how a changed Config field can be applied to a running server.
+type FieldAction string
+
+// Possible actions a config field change can require.
+const (
+	ActionHotRCON FieldAction = "hot-rcon" // can be applied to a running server via an RCON command
+	ActionRestart FieldAction = "restart"  // requires the server process to be restarted
+	ActionIgnored FieldAction = "ignored"  // not part of server.cfg, has no runtime effect
+)
+
+// hotRCONCommands maps Config struct field names to the RCON command used to apply a change to
+// that field on a running server without a restart.
+var hotRCONCommands = map[string]string{
+	"Hostname":  "hostname",
+	"Mapname":   "mapname",
+	"Weburl":    "weburl",
+	"Gamemodes": "gmx",
+}
+
+// ignoredFields are struct fields that don't correspond to a server.cfg directive and therefore
+// never require an RCON command or a restart.
+var ignoredFields = map[string]bool{
+	"dir":      true,
+	"Version":  true,
+	"Endpoint": true,
+	"Echo":     true,
+}
+
+// FieldChange describes a single field that differs between an old and new Config.
+type FieldChange struct {
+	Field   string      `json:"field"`
+	Action  FieldAction `json:"action"`
+	Command string      `json:"command,omitempty"` // RCON command name, set when Action is ActionHotRCON
+	Old     interface{} `json:"old"`
+	New     interface{} `json:"new"`
+}
+
+// DiffConfig compares two Configs field by field and classifies each difference as hot-applicable
+// via RCON or requiring a restart. Differences in ignoredFields are left out of the result.
+func DiffConfig(old, new Config) (changes []FieldChange) {
+	ov := reflect.ValueOf(old)
+	nv := reflect.ValueOf(new)
+	t := ov.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Name
+		if ignoredFields[name] {
+			continue
+		}
+
+		of := ov.Field(i)
+		nf := nv.Field(i)
+		if !of.CanInterface() || !nf.CanInterface() {
+			continue
+		}
+
+		if reflect.DeepEqual(of.Interface(), nf.Interface()) {
+			continue
+		}
+
+		change := FieldChange{
+			Field: name,
+			Old:   derefForDiff(of),
+			New:   derefForDiff(nf),
+		}
+
+		if command, ok := hotRCONCommands[name]; ok {
+			change.Action = ActionHotRCON
+			change.Command = command
+		} else {
+			change.Action = ActionRestart
+		}
+
+		changes = append(changes, change)
+	}
+
+	return
+}
+
+// derefForDiff unwraps pointer/slice fields into plain values for comparison and JSON output.
+func derefForDiff(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		return v.Elem().Interface()
+	}
+	return v.Interface()
+}
+
+// Follower watches a directory for samp.json/samp.yaml changes and applies them to a running
+// server, refusing changes that require a restart unless AllowRestart is set.
+type Follower struct {
+	Dir          string
+	RCON         RCONClient // nil disables RCON application, changes are reported but not sent
+	AllowRestart bool       // if false, a restart-requiring change refuses the whole reload: nothing is applied and the refused change is emitted as an event, not returned as an error
+
+	watcher *fsnotify.Watcher
+	events  chan FieldChange
+}
+
+// NewFollower creates a Follower for the given directory. Call Run to start watching.
+func NewFollower(dir string) *Follower {
+	return &Follower{
+		Dir:    dir,
+		events: make(chan FieldChange, 16),
+	}
+}
+
+// Events returns a channel of field changes from each reload, including the refused change when a
+// reload is refused. Intended for subscribers such as the HTTP stream handler below.
+func (f *Follower) Events() <-chan FieldChange {
+	return f.events
+}
+
+// Run watches f.Dir for samp.json/samp.yaml changes until ctx is cancelled, calling onChange
+// after each reload with the previous and new Config. Non-restart-safe changes are sent over
+// RCON (if f.RCON is set) or refused, unless f.AllowRestart is true.
+func (f *Follower) Run(ctx context.Context, onChange func(old, new Config) error) (err error) {
+	f.watcher, err = fsnotify.NewWatcher()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create fsnotify watcher")
+		return
+	}
+	defer f.watcher.Close() // nolint:errcheck
+
+	if err = f.watcher.Add(f.Dir); err != nil {
+		err = errors.Wrap(err, "failed to watch directory")
+		return
+	}
+
+	current, err := ConfigFromDirectory(f.Dir)
+	if err != nil {
+		err = errors.Wrap(err, "failed to load initial config")
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-f.watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+
+			next, cerr := ConfigFromDirectory(f.Dir)
+			if cerr != nil {
+				continue // transient read during a multi-step write, wait for the next event
+			}
+
+			refused, aerr := f.apply(current, next)
+			if aerr != nil {
+				return aerr
+			}
+			if refused != nil {
+				// nothing was applied - report the refusal and keep watching from the same
+				// current config, rather than treating it as fatal to the whole follower.
+				f.emit(*refused)
+				continue
+			}
+
+			if onChange != nil {
+				if err = onChange(current, next); err != nil {
+					return err
+				}
+			}
+
+			current = next
+
+		case werr, ok := <-f.watcher.Errors:
+			if !ok {
+				return nil
+			}
+			return errors.Wrap(werr, "fsnotify error")
+		}
+	}
+}
+
+// apply classifies the diff between old and new. If any field requires a restart and
+// AllowRestart is false, the whole reload is refused - refused is returned describing the
+// offending field and nothing is applied, not even other fields' hot-RCON commands. Otherwise
+// every hot-applicable field is sent over RCON (if f.RCON is set).
+func (f *Follower) apply(old, new Config) (refused *FieldChange, err error) {
+	changes := DiffConfig(old, new)
+
+	for _, change := range changes {
+		if change.Action == ActionRestart && !f.AllowRestart {
+			change := change
+			refused = &change
+			return
+		}
+	}
+
+	for _, change := range changes {
+		if change.Action == ActionHotRCON && f.RCON != nil {
+			if _, rerr := f.RCON.Send(rconCommand(change)); rerr != nil {
+				err = errors.Wrapf(rerr, "failed to apply %s via rcon", change.Field)
+				return
+			}
+		}
+
+		f.emit(change)
+	}
+
+	return
+}
+
+// rconCommand builds the RCON command line for a hot-applicable change. gmx (gamemode rotation)
+// takes no arguments, it just advances to the next configured gamemode.
+func rconCommand(change FieldChange) string {
+	if change.Field == "Gamemodes" {
+		return change.Command
+	}
+	return fmt.Sprintf("%s %v", change.Command, change.New)
+}
+
+// emit pushes a change onto the events channel, dropping it when the channel's buffer is full
+// since the channel is best-effort for subscribers.
+func (f *Follower) emit(change FieldChange) {
+	select {
+	case f.events <- change:
+	default:
+	}
+}
+
+// Watch is a convenience wrapper around Follower for callers that don't need RCON application or
+// the event stream, just a callback on each accepted reload. AllowRestart is false, so a reload
+// containing a restart-requiring change is refused and does not reach onChange.
+func Watch(ctx context.Context, dir string, onChange func(old, new Config) error) error {
+	return NewFollower(dir).Run(ctx, onChange)
+}
+
+// ServeEvents exposes f.Events() as a newline-delimited JSON stream over HTTP, so external
+// tooling can subscribe to config-change events without embedding the Go package.
+func (f *Follower) ServeEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	enc := json.NewEncoder(w)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case change, ok := <-f.events:
+			if !ok {
+				return
+			}
+			if err := enc.Encode(change); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package runtime
+
+import "testing"
+
+func TestDiffConfig_ClassifiesHotRCONField(t *testing.T) {
+	old := Config{Hostname: strPtr("old")}
+	new := Config{Hostname: strPtr("new")}
+
+	changes := DiffConfig(old, new)
+
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 change, got %d: %+v", len(changes), changes)
+	}
+	if changes[0].Action != ActionHotRCON || changes[0].Command != "hostname" {
+		t.Fatalf("expected hostname to be hot-rcon applicable via 'hostname', got %+v", changes[0])
+	}
+}
+
+func TestDiffConfig_ClassifiesRestartField(t *testing.T) {
+	old := Config{Port: intPtr(7777)}
+	new := Config{Port: intPtr(7778)}
+
+	changes := DiffConfig(old, new)
+
+	if len(changes) != 1 || changes[0].Action != ActionRestart {
+		t.Fatalf("expected port change to require a restart, got %+v", changes)
+	}
+}
+
+func TestDiffConfig_IgnoresNonServerCfgFields(t *testing.T) {
+	oldVersion := "1.0"
+	newVersion := "2.0"
+	old := Config{Version: &oldVersion}
+	new := Config{Version: &newVersion}
+
+	changes := DiffConfig(old, new)
+
+	if len(changes) != 0 {
+		t.Fatalf("expected Version changes to be ignored, got %+v", changes)
+	}
+}
+
+func TestDiffConfig_NoChanges(t *testing.T) {
+	cfg := Config{Hostname: strPtr("same")}
+
+	changes := DiffConfig(cfg, cfg)
+
+	if len(changes) != 0 {
+		t.Fatalf("expected no changes for identical configs, got %+v", changes)
+	}
+}
+
+// fakeRCONClient records every command it's sent, so tests can assert whether a reload actually
+// reached RCON or was refused before anything was applied.
+type fakeRCONClient struct {
+	sent []string
+}
+
+func (f *fakeRCONClient) Send(command string) (string, error) {
+	f.sent = append(f.sent, command)
+	return "", nil
+}
+
+func TestFollowerApply_RefusesRestartRequiringChangeWithoutApplyingHotFields(t *testing.T) {
+	rcon := &fakeRCONClient{}
+	f := &Follower{RCON: rcon, AllowRestart: false, events: make(chan FieldChange, 16)}
+
+	old := Config{Hostname: strPtr("old"), Port: intPtr(7777)}
+	new := Config{Hostname: strPtr("new"), Port: intPtr(7778)}
+
+	refused, err := f.apply(old, new)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refused == nil || refused.Field != "Port" {
+		t.Fatalf("expected the restart-requiring Port change to be refused, got %+v", refused)
+	}
+	if len(rcon.sent) != 0 {
+		t.Fatalf("expected no RCON commands to be sent when the reload is refused, got %v", rcon.sent)
+	}
+}
+
+func TestFollowerApply_SendsHotRCONFieldsWhenNoRestartRequired(t *testing.T) {
+	rcon := &fakeRCONClient{}
+	f := &Follower{RCON: rcon, events: make(chan FieldChange, 16)}
+
+	old := Config{Hostname: strPtr("old")}
+	new := Config{Hostname: strPtr("new")}
+
+	refused, err := f.apply(old, new)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refused != nil {
+		t.Fatalf("expected no refusal, got %+v", refused)
+	}
+	if len(rcon.sent) != 1 || rcon.sent[0] != "hostname new" {
+		t.Fatalf("expected 'hostname new' to be sent over rcon, got %v", rcon.sent)
+	}
+}
+
+func TestFollowerApply_AllowRestartLetsRestartFieldThrough(t *testing.T) {
+	rcon := &fakeRCONClient{}
+	f := &Follower{RCON: rcon, AllowRestart: true, events: make(chan FieldChange, 16)}
+
+	old := Config{Port: intPtr(7777)}
+	new := Config{Port: intPtr(7778)}
+
+	refused, err := f.apply(old, new)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refused != nil {
+		t.Fatalf("expected no refusal when AllowRestart is true, got %+v", refused)
+	}
+}
+
+func TestRconCommand_GamemodesTakesNoArgument(t *testing.T) {
+	change := FieldChange{Field: "Gamemodes", Command: "gmx", New: []string{"rivershell", "ctf"}}
+
+	if got := rconCommand(change); got != "gmx" {
+		t.Fatalf("expected bare 'gmx' with no argument, got %q", got)
+	}
+}
+
+func TestRconCommand_OtherFieldsIncludeNewValue(t *testing.T) {
+	change := FieldChange{Field: "Hostname", Command: "hostname", New: "new server name"}
+
+	if got := rconCommand(change); got != "hostname new server name" {
+		t.Fatalf("expected 'hostname new server name', got %q", got)
+	}
+}